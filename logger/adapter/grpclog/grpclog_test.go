@@ -0,0 +1,48 @@
+package grpclog
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/grpclog"
+
+	"github.com/uncopied/tools/logger"
+)
+
+// var declaration, not inside the test func, so a future grpclog.LoggerV2
+// signature change fails to compile instead of silently breaking callers
+// that type-assert *Logger against it.
+var _ grpclog.LoggerV2 = (*Logger)(nil)
+
+func TestNewLoggerSatisfiesLoggerV2(t *testing.T) {
+	var l grpclog.LoggerV2 = NewLogger()
+	if l == nil {
+		t.Fatal("NewLogger returned nil")
+	}
+}
+
+func TestLoggerWritesThroughGlobalLogger(t *testing.T) {
+	sink := logger.NewMemorySink(0)
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "message",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})
+	logger.SetLogger(zap.New(zapcore.NewCore(encoder, sink, zapcore.DebugLevel)).Sugar())
+
+	l := NewLogger()
+	l.Warning("disk is full")
+
+	lines := sink.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line written, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], `"level":"warn"`) {
+		t.Fatalf("expected warn level in output, got %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "disk is full") {
+		t.Fatalf("expected message in output, got %q", lines[0])
+	}
+}
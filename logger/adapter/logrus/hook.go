@@ -0,0 +1,74 @@
+// Package logrus bridges github.com/sirupsen/logrus into this module's
+// logger, so call sites written against logrus keep working while every
+// entry is funneled through the shared zap core and its context/tags/
+// version enrichment.
+package logrus
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/uncopied/tools/logger"
+)
+
+// Hook forwards logrus entries into the module's zap core, preserving
+// fields and level. It holds no state of its own: Fire re-resolves
+// logger.Logger() on every call so a later SetLogger/WatchAndRebuildLogger
+// rebuild (new sinks, a log level change, ...) is picked up immediately.
+type Hook struct{}
+
+// NewHook returns a logrus.Hook that writes through the current global
+// logger.
+func NewHook() *Hook {
+	return &Hook{}
+}
+
+// Levels reports every level logrus may fire the hook for.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire converts entry into a zap entry and writes it through the
+// current global logger's core.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	lvl, err := levelFromLogrus(entry.Level)
+	if err != nil {
+		return err
+	}
+
+	ce := logger.Logger().Desugar().Check(lvl, entry.Message)
+	if ce == nil {
+		return nil
+	}
+
+	fields := make([]zap.Field, 0, len(entry.Data))
+	for k, v := range entry.Data {
+		fields = append(fields, zap.Any(k, v))
+	}
+
+	ce.Write(fields...)
+
+	return nil
+}
+
+func levelFromLogrus(lvl logrus.Level) (zapcore.Level, error) {
+	switch lvl {
+	case logrus.PanicLevel:
+		return zapcore.PanicLevel, nil
+	case logrus.FatalLevel:
+		return zapcore.FatalLevel, nil
+	case logrus.ErrorLevel:
+		return zapcore.ErrorLevel, nil
+	case logrus.WarnLevel:
+		return zapcore.WarnLevel, nil
+	case logrus.InfoLevel:
+		return zapcore.InfoLevel, nil
+	case logrus.DebugLevel, logrus.TraceLevel:
+		return zapcore.DebugLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("unknown logrus level: %v", lvl)
+	}
+}
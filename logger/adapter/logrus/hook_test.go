@@ -0,0 +1,75 @@
+package logrus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/uncopied/tools/logger"
+)
+
+func TestLevelFromLogrus(t *testing.T) {
+	cases := []struct {
+		in   logrus.Level
+		want zapcore.Level
+	}{
+		{logrus.PanicLevel, zapcore.PanicLevel},
+		{logrus.FatalLevel, zapcore.FatalLevel},
+		{logrus.ErrorLevel, zapcore.ErrorLevel},
+		{logrus.WarnLevel, zapcore.WarnLevel},
+		{logrus.InfoLevel, zapcore.InfoLevel},
+		{logrus.DebugLevel, zapcore.DebugLevel},
+		{logrus.TraceLevel, zapcore.DebugLevel},
+	}
+
+	for _, tc := range cases {
+		got, err := levelFromLogrus(tc.in)
+		if err != nil {
+			t.Fatalf("levelFromLogrus(%v) returned error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("levelFromLogrus(%v) = %v, want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestLevelFromLogrusUnknown(t *testing.T) {
+	if _, err := levelFromLogrus(logrus.Level(99)); err == nil {
+		t.Fatal("expected an error for an unrecognized logrus level")
+	}
+}
+
+func TestHookFireWritesThroughGlobalLogger(t *testing.T) {
+	sink := logger.NewMemorySink(0)
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "message",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})
+	logger.SetLogger(zap.New(zapcore.NewCore(encoder, sink, zapcore.DebugLevel)).Sugar())
+
+	hook := NewHook()
+	entry := &logrus.Entry{
+		Level:   logrus.WarnLevel,
+		Message: "disk is full",
+		Data:    logrus.Fields{"disk": "/dev/sda1"},
+	}
+
+	if err := hook.Fire(entry); err != nil {
+		t.Fatalf("Fire returned error: %v", err)
+	}
+
+	lines := sink.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line written, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], `"level":"warn"`) {
+		t.Fatalf("expected warn level in output, got %q", lines[0])
+	}
+	if !strings.Contains(lines[0], `"disk":"/dev/sda1"`) {
+		t.Fatalf("expected disk field in output, got %q", lines[0])
+	}
+}
@@ -0,0 +1,37 @@
+// Package stdlog exposes this module's logger through the standard
+// library's *log.Logger interface, so code that only knows about
+// log.Logger can still go through the shared zap core.
+package stdlog
+
+import (
+	"log"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/uncopied/tools/logger"
+)
+
+// writer re-resolves logger.Logger() on every Write, so the *log.Logger
+// NewStdLogger hands out keeps following a later SetLogger/
+// WatchAndRebuildLogger rebuild instead of freezing whatever core was
+// live when it was constructed.
+type writer struct {
+	level zapcore.Level
+}
+
+func (w writer) Write(p []byte) (int, error) {
+	msg := strings.TrimRight(string(p), "\n")
+
+	if ce := logger.Logger().Desugar().Check(w.level, msg); ce != nil {
+		ce.Write()
+	}
+
+	return len(p), nil
+}
+
+// NewStdLogger returns a *log.Logger that writes through the current
+// global logger, tagging every line with lvl.
+func NewStdLogger(lvl zapcore.Level) *log.Logger {
+	return log.New(writer{level: lvl}, "", 0)
+}
@@ -0,0 +1,35 @@
+package stdlog
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/uncopied/tools/logger"
+)
+
+func TestNewStdLoggerWritesThroughGlobalLogger(t *testing.T) {
+	sink := logger.NewMemorySink(0)
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "message",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})
+	logger.SetLogger(zap.New(zapcore.NewCore(encoder, sink, zapcore.DebugLevel)).Sugar())
+
+	stdLogger := NewStdLogger(zapcore.WarnLevel)
+	stdLogger.Print("disk is full")
+
+	lines := sink.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line written, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], `"level":"warn"`) {
+		t.Fatalf("expected warn level in output, got %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "disk is full") {
+		t.Fatalf("expected message in output, got %q", lines[0])
+	}
+}
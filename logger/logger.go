@@ -1,36 +1,42 @@
-package main
+package logger
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/vrischmann/envconfig"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	loggerv1 "github.com/uncopied/tools/logger/v1"
 )
 
 const tagsName = "hashtags"
 
 type Config struct {
-	LogLevel   string `envconfig:"default=info"`
-	MessageKey string `envconfig:"default=message"`
-	LevelKey   string `envconfig:"default=severity"`
-	TimeKey    string `envconfig:"default=timestamp"`
-	AppName    string `envconfig:"default=app"`
-	Host       string `envconfig:"default=localhost"`
-	Version    string `envconfig:"default=0.0.0"`
-	DevMode    bool   `envconfig:"default=false"`
+	LogLevel   string       `envconfig:"default=info"`
+	MessageKey string       `envconfig:"default=message"`
+	LevelKey   string       `envconfig:"default=severity"`
+	TimeKey    string       `envconfig:"default=timestamp"`
+	AppName    string       `envconfig:"default=app"`
+	Host       string       `envconfig:"default=localhost"`
+	Version    string       `envconfig:"default=0.0.0"`
+	DevMode    bool         `envconfig:"default=false"`
+	Sinks      []SinkConfig `envconfig:"optional"`
+
+	SamplingInitial    int           `envconfig:"default=100"`
+	SamplingThereafter int           `envconfig:"default=100"`
+	SamplingTick       time.Duration `envconfig:"default=1s"`
+	RateLimitPerSec    int           `envconfig:"optional"`
 }
 
 var (
-	// global logger instance.
-	global      *zap.SugaredLogger
-	globalGuard sync.RWMutex
-
 	level      = zap.NewAtomicLevelAt(zap.InfoLevel)
 	defaultCfg = Config{
 		LogLevel:   "info",
@@ -86,6 +92,70 @@ func WatchAndRebuildLogger(ctx context.Context, prefix, version string, cfg *Con
 		cfg.Version = version
 		SetLogger(New(lvl, cfg))
 	})
+
+	w.OnConfigChange(prefix+"_LOG_PACKAGE_LEVELS", func(newVal interface{}) {
+		raw, ok := newVal.(string)
+		if !ok {
+			safeErrorf("Failed to cast newVal to string, got type %T", newVal)
+			return
+		}
+
+		var levels map[string]string
+		if err := json.Unmarshal([]byte(raw), &levels); err != nil {
+			safeErrorf("Failed to unmarshal package log levels: %s; err: %v", raw, err)
+			return
+		}
+
+		for pkg, lvl := range levels {
+			if err := SetPackageLogLevel(pkg, lvl); err != nil {
+				safeErrorf("Failed to set log level for package %s to %s; err: %v", pkg, lvl, err)
+			}
+		}
+	})
+
+	w.OnConfigChange(prefix+"_LOG_TRACE_PUBLISH", func(newVal interface{}) {
+		raw, ok := newVal.(string)
+		if !ok {
+			safeErrorf("Failed to cast newVal to string, got type %T", newVal)
+			return
+		}
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			safeErrorf("Failed to parse trace publish flag: %s; err: %v", raw, err)
+			return
+		}
+		Features.SetTracePublish(enabled)
+	})
+
+	w.OnConfigChange(prefix+"_LOG_CORRELATION", func(newVal interface{}) {
+		raw, ok := newVal.(string)
+		if !ok {
+			safeErrorf("Failed to cast newVal to string, got type %T", newVal)
+			return
+		}
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			safeErrorf("Failed to parse log correlation flag: %s; err: %v", raw, err)
+			return
+		}
+		Features.SetLogCorrelation(enabled)
+	})
+
+	w.OnConfigChange(prefix+"_LOG_SINKS", func(newVal interface{}) {
+		raw, ok := newVal.(string)
+		if !ok {
+			safeErrorf("Failed to cast newVal to string, got type %T", newVal)
+			return
+		}
+
+		var sinks []SinkConfig
+		if err := json.Unmarshal([]byte(raw), &sinks); err != nil {
+			safeErrorf("Failed to unmarshal log sinks: %s; err: %v", raw, err)
+			return
+		}
+
+		reloadSinks(cfg, sinks)
+	})
 }
 
 func safeErrorf(format string, args ...interface{}) {
@@ -100,14 +170,64 @@ func zapLevelFromString(newLogLevel string) (zap.AtomicLevel, error) {
 	return lvl, err
 }
 
-// New creates new *zap.SugaredLogger with standard EncoderConfig
+// New creates new *zap.SugaredLogger with standard EncoderConfig. Output
+// goes through a lockedMultiCore seeded with a stdout sink plus whatever
+// sinks are listed in cfg.Sinks; RegisterSink/RemoveSink can add or drop
+// sinks afterwards without recreating the logger.
 func New(lvl zapcore.LevelEnabler, cfg *Config, options ...zap.Option) *zap.SugaredLogger {
 	if lvl == nil {
 		lvl = level
 	}
-	sink := zapcore.AddSync(os.Stdout)
-	options = append(options, zap.ErrorOutput(sink))
+	options = append(options, zap.ErrorOutput(zapcore.AddSync(os.Stdout)))
+
+	encoder := buildEncoder(cfg)
+
+	mc := newLockedMultiCore()
+	mc.RegisterSink("stdout", zapcore.AddSync(os.Stdout), encoder, lvl)
 
+	for _, sc := range cfg.Sinks {
+		sink, err := buildSink(sc)
+		if err != nil {
+			safeErrorf("Failed to build log sink %s: %v", sc.Name, err)
+			continue
+		}
+
+		var sinkLevel zapcore.LevelEnabler = lvl
+		if parsed, err := zapLevelFromString(sc.Level); err == nil {
+			sinkLevel = parsed
+		}
+
+		mc.RegisterSink(sc.Name, sink, encoder, sinkLevel)
+	}
+
+	setCurrentMultiCore(mc)
+	seedAppliedSinks(cfg.Sinks)
+
+	if cfg.SamplingInitial > 0 || cfg.SamplingThereafter > 0 {
+		tick := cfg.SamplingTick
+		if tick <= 0 {
+			tick = DefaultSamplingTick
+		}
+		first := cfg.SamplingInitial
+		if first <= 0 {
+			first = DefaultSamplingInitial
+		}
+		thereafter := cfg.SamplingThereafter
+		if thereafter <= 0 {
+			thereafter = DefaultSamplingThereafter
+		}
+
+		options = append(options, WithSampling(tick, first, thereafter))
+	}
+
+	if cfg.RateLimitPerSec > 0 {
+		options = append(options, WithRateLimit(cfg.RateLimitPerSec, cfg.RateLimitPerSec))
+	}
+
+	return zap.New(mc, options...).With(getZapFields(cfg)...).Sugar()
+}
+
+func buildEncoder(cfg *Config) zapcore.Encoder {
 	config := zapcore.EncoderConfig{
 		TimeKey:        cfg.TimeKey,
 		LevelKey:       cfg.LevelKey,
@@ -120,16 +240,14 @@ func New(lvl zapcore.LevelEnabler, cfg *Config, options ...zap.Option) *zap.Suga
 		EncodeDuration: zapcore.SecondsDurationEncoder,
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
-	var encoder zapcore.Encoder
+
 	if cfg.DevMode {
 		config.EncodeLevel = zapcore.LowercaseColorLevelEncoder
-		encoder = zapcore.NewConsoleEncoder(config)
-	} else {
-		config.EncodeLevel = zapcore.LowercaseLevelEncoder
-		encoder = zapcore.NewJSONEncoder(config)
+		return zapcore.NewConsoleEncoder(config)
 	}
 
-	return zap.New(zapcore.NewCore(encoder, sink, lvl), options...).With(getZapFields(cfg)...).Sugar()
+	config.EncodeLevel = zapcore.LowercaseLevelEncoder
+	return zapcore.NewJSONEncoder(config)
 }
 
 func getZapFields(config *Config) []zapcore.Field {
@@ -150,133 +268,132 @@ func getZapFields(config *Config) []zapcore.Field {
 	return fields
 }
 
-// Logger returns current global logger.
+// Logger returns current global logger. It's a thin wrapper around
+// logger/v1's Logger/SetLogger, which hold the actual state so that
+// loggerv1.FromContext (used by Debug/Info/... below) always observes
+// the same logger this package hands out.
 func Logger() *zap.SugaredLogger {
-	globalGuard.RLock()
-	defer globalGuard.RUnlock()
-	return global
+	return loggerv1.Logger()
 }
 
 // SetLogger sets global used logger. This function is not thread-safe.
 func SetLogger(l *zap.SugaredLogger) {
-	globalGuard.Lock()
-	defer globalGuard.Unlock()
-	global = l
+	loggerv1.SetLogger(l)
 }
 
 func Debug(ctx context.Context, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		DebugKV(ctx, fmt.Sprint(args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Debug(args...)
+	loggerv1.FromContext(ctx).Debug(args...)
 }
 
 func Debugf(ctx context.Context, format string, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		DebugKV(ctx, fmt.Sprintf(format, args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Debugf(format, args...)
+	loggerv1.FromContext(ctx).Debugf(format, args...)
 }
 
 func DebugKV(ctx context.Context, message string, kvs ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		kvs = append(kvs, tagsName, prepareTags(tags))
 	}
-	FromContext(ctx).Debugw(message, kvs...)
+	loggerv1.FromContext(ctx).Debugw(message, kvs...)
 }
 
 func Info(ctx context.Context, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		InfoKV(ctx, fmt.Sprint(args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Info(args...)
+	loggerv1.FromContext(ctx).Info(args...)
 }
 
 func Infof(ctx context.Context, format string, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		InfoKV(ctx, fmt.Sprintf(format, args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Infof(format, args...)
+	loggerv1.FromContext(ctx).Infof(format, args...)
 }
 
 func InfoKV(ctx context.Context, message string, kvs ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		kvs = append(kvs, tagsName, prepareTags(tags))
 	}
-	FromContext(ctx).Infow(message, kvs...)
+	loggerv1.FromContext(ctx).Infow(message, kvs...)
 }
 
 func Warn(ctx context.Context, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		WarnKV(ctx, fmt.Sprint(args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Warn(args...)
+	loggerv1.FromContext(ctx).Warn(args...)
 }
 
 func Warnf(ctx context.Context, format string, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		WarnKV(ctx, fmt.Sprintf(format, args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Warnf(format, args...)
+	loggerv1.FromContext(ctx).Warnf(format, args...)
 }
 
 func WarnKV(ctx context.Context, message string, kvs ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		kvs = append(kvs, tagsName, prepareTags(tags))
 	}
-	FromContext(ctx).Warnw(message, kvs...)
+	loggerv1.FromContext(ctx).Warnw(message, kvs...)
 }
 
 func Error(ctx context.Context, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		ErrorKV(ctx, fmt.Sprint(args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Error(args...)
+	loggerv1.FromContext(ctx).Error(args...)
 }
 
 func Errorf(ctx context.Context, format string, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		ErrorKV(ctx, fmt.Sprintf(format, args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Errorf(format, args...)
+	loggerv1.FromContext(ctx).Errorf(format, args...)
 }
 
 func ErrorKV(ctx context.Context, message string, kvs ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		kvs = append(kvs, tagsName, prepareTags(tags))
 	}
-	FromContext(ctx).Errorw(message, kvs...)
+	loggerv1.FromContext(ctx).Errorw(message, kvs...)
 }
 
 func Fatal(ctx context.Context, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		FatalKV(ctx, fmt.Sprint(args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Fatal(args...)
+	loggerv1.FromContext(ctx).Fatal(args...)
 }
 
 func Fatalf(ctx context.Context, format string, args ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		FatalKV(ctx, fmt.Sprintf(format, args...), tagsName, prepareTags(tags))
 		return
 	}
-	FromContext(ctx).Fatalf(format, args...)
+	loggerv1.FromContext(ctx).Fatalf(format, args...)
 }
 
 func FatalKV(ctx context.Context, message string, kvs ...interface{}) {
-	if tags, ok := ctx.Value(loggerContextTags).([]string); ok {
+	if tags, ok := loggerv1.TagsFromContext(ctx); ok {
 		kvs = append(kvs, tagsName, prepareTags(tags))
 	}
-	FromContext(ctx).Fatalw(message, kvs...)
+	loggerv1.FromContext(ctx).Fatalw(message, kvs...)
 }
 
 func prepareTags(tags []string) string {
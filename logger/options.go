@@ -7,7 +7,7 @@ import (
 
 type coreWithLevel struct {
 	zapcore.Core
-	level zapcore.Level
+	level zapcore.LevelEnabler
 }
 
 func (c *coreWithLevel) Enabled(l zapcore.Level) bool {
@@ -30,12 +30,14 @@ func (c *coreWithLevel) With(fields []zapcore.Field) zapcore.Core {
 }
 
 // WithLevel returns `zap.Option` that can be used to create a new logger
-// from an existing one with a new logging level
+// from an existing one with a new logging level. lvl may be a plain
+// zapcore.Level or a zap.AtomicLevel, allowing the level to be changed
+// later without rebuilding the core.
 //
 // Usage:
 //     logger.Logger().Desugar().WithOptions(logger.WithLevel(level)).Sugar()
 //
-func WithLevel(lvl zapcore.Level) zap.Option {
+func WithLevel(lvl zapcore.LevelEnabler) zap.Option {
 	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
 		return &coreWithLevel{core, lvl}
 	})
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRegisterPackageAndSetLevel(t *testing.T) {
+	New(nil, &Config{})
+
+	if l := RegisterPackage("widget", zapcore.InfoLevel); l == nil {
+		t.Fatal("RegisterPackage returned a nil logger")
+	}
+
+	if got := ListPackages()["widget"]; got != zapcore.InfoLevel {
+		t.Fatalf("expected widget package level info, got %v", got)
+	}
+
+	if err := SetPackageLogLevel("widget", "debug"); err != nil {
+		t.Fatalf("SetPackageLogLevel failed: %v", err)
+	}
+
+	if got := ListPackages()["widget"]; got != zapcore.DebugLevel {
+		t.Fatalf("expected widget package level debug after update, got %v", got)
+	}
+
+	if err := SetPackageLogLevel("does-not-exist", "debug"); err == nil {
+		t.Fatal("expected an error for an unregistered package")
+	}
+}
+
+func TestSetAllLogLevel(t *testing.T) {
+	New(nil, &Config{})
+
+	RegisterPackage("pkg-a", zapcore.InfoLevel)
+	RegisterPackage("pkg-b", zapcore.InfoLevel)
+
+	if err := SetAllLogLevel("error"); err != nil {
+		t.Fatalf("SetAllLogLevel failed: %v", err)
+	}
+
+	for name, lvl := range ListPackages() {
+		if lvl != zapcore.ErrorLevel {
+			t.Fatalf("expected package %s at error level, got %v", name, lvl)
+		}
+	}
+}
+
+func TestRegisterPackageFollowsSinksAddedAfterRegistration(t *testing.T) {
+	SetLogger(New(nil, &Config{}))
+
+	pkgLogger := RegisterPackage("gadget", zapcore.InfoLevel)
+
+	sink := NewMemorySink(0)
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "message",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})
+	RegisterSink("gadget-sink", sink, encoder, zapcore.InfoLevel)
+	defer RemoveSink("gadget-sink")
+
+	pkgLogger.Info("widget assembled")
+
+	lines := sink.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line written to the sink registered after RegisterPackage, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], `"package":"gadget"`) {
+		t.Fatalf("expected package field in output, got %q", lines[0])
+	}
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
+)
+
+// Default sampling thresholds, mirroring etcd's DefaultZapLoggerConfig:
+// the first SamplingInitial entries per SamplingTick pass through
+// unsampled, then only every SamplingThereafter-th one does.
+const (
+	DefaultSamplingInitial    = 100
+	DefaultSamplingThereafter = 100
+	DefaultSamplingTick       = time.Second
+)
+
+// WithSampling returns a zap.Option that thins out repeated log entries:
+// within each tick, the first `first` entries for a given level/message
+// pass through, then only every `thereafter`-th one does. New composes
+// this automatically from Config.Sampling*.
+func WithSampling(tick time.Duration, first, thereafter int) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, tick, first, thereafter)
+	})
+}
+
+// WithRateLimit returns a zap.Option that drops entries once more than
+// perSecond (with burst allowance burst) have been written in the last
+// second, emitting a periodic "N messages dropped" summary in place of
+// the entries it discards so the drop itself isn't silent.
+func WithRateLimit(perSecond, burst int) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &rateLimitedCore{
+			Core:      core,
+			limiter:   rate.NewLimiter(rate.Limit(perSecond), burst),
+			perSecond: perSecond,
+		}
+	})
+}
+
+type rateLimitedCore struct {
+	zapcore.Core
+	limiter   *rate.Limiter
+	perSecond int
+	dropped   uint64
+}
+
+func (c *rateLimitedCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c *rateLimitedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.limiter.Allow() {
+		return c.Core.Write(ent, fields)
+	}
+
+	dropped := atomic.AddUint64(&c.dropped, 1)
+	if dropped%uint64(c.perSecond) != 0 {
+		return nil
+	}
+
+	return c.Core.Write(zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    ent.Time,
+		Message: fmt.Sprintf("%d messages dropped due to rate limiting", dropped),
+	}, nil)
+}
+
+func (c *rateLimitedCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitedCore{
+		Core:      c.Core.With(fields),
+		limiter:   c.limiter,
+		perSecond: c.perSecond,
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithRateLimitDropsExcessEntries(t *testing.T) {
+	sink := NewMemorySink(0)
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		MessageKey:  "message",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	})
+	core := zapcore.NewCore(encoder, sink, zapcore.DebugLevel)
+
+	l := zap.New(core, WithRateLimit(10, 1))
+
+	const total = 200
+	for i := 0; i < total; i++ {
+		l.Info("hello")
+	}
+
+	if got := len(sink.Lines()); got >= total {
+		t.Fatalf("expected WithRateLimit to drop entries once the burst is exhausted, got %d of %d lines through", got, total)
+	}
+}
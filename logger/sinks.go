@@ -0,0 +1,276 @@
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+	"net"
+	"os"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+	lumberjack "gopkg.in/natefinch/lumberjack.v2"
+)
+
+// SinkConfig describes one named output to be registered on the
+// lockedMultiCore built by New. Type selects which kind of
+// zapcore.WriteSyncer is dialed/opened for Path.
+type SinkConfig struct {
+	Name  string `envconfig:"optional"`
+	Type  string `envconfig:"optional"` // stdout, file, syslog, tcp, udp, memory
+	Path  string `envconfig:"optional"` // file path or network address; unused for syslog, which always uses the local daemon
+	Level string `envconfig:"optional"`
+}
+
+// lockedMultiCore fans log entries out to a set of named zapcore.Core
+// sinks. It is safe for concurrent mutation via RegisterSink/RemoveSink
+// while entries are being written, so sinks can be added or removed live
+// without replacing the global logger pointer.
+type lockedMultiCore struct {
+	mu    sync.RWMutex
+	cores map[string]zapcore.Core
+}
+
+func newLockedMultiCore() *lockedMultiCore {
+	return &lockedMultiCore{cores: make(map[string]zapcore.Core)}
+}
+
+// RegisterSink adds or replaces a named sink.
+func (m *lockedMultiCore) RegisterSink(name string, sink zapcore.WriteSyncer, enc zapcore.Encoder, lvl zapcore.LevelEnabler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cores[name] = zapcore.NewCore(enc, sink, lvl)
+}
+
+// RemoveSink stops forwarding entries to the named sink.
+func (m *lockedMultiCore) RemoveSink(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.cores, name)
+}
+
+func (m *lockedMultiCore) Enabled(lvl zapcore.Level) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, c := range m.cores {
+		if c.Enabled(lvl) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *lockedMultiCore) With(fields []zapcore.Field) zapcore.Core {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cores := make(map[string]zapcore.Core, len(m.cores))
+	for name, c := range m.cores {
+		cores[name] = c.With(fields)
+	}
+
+	return &lockedMultiCore{cores: cores}
+}
+
+func (m *lockedMultiCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, c := range m.cores {
+		ce = c.Check(ent, ce)
+	}
+
+	return ce
+}
+
+func (m *lockedMultiCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var err error
+	for name, c := range m.cores {
+		if werr := c.Write(ent, fields); werr != nil {
+			err = multierr.Append(err, fmt.Errorf("sink %s: %w", name, werr))
+		}
+	}
+
+	return err
+}
+
+func (m *lockedMultiCore) Sync() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var err error
+	for name, c := range m.cores {
+		if serr := c.Sync(); serr != nil {
+			err = multierr.Append(err, fmt.Errorf("sink %s: %w", name, serr))
+		}
+	}
+
+	return err
+}
+
+var (
+	currentMultiCoreGuard sync.RWMutex
+	currentMultiCore      *lockedMultiCore
+
+	appliedSinksGuard sync.Mutex
+	appliedSinks      = map[string]bool{}
+)
+
+func setCurrentMultiCore(mc *lockedMultiCore) {
+	currentMultiCoreGuard.Lock()
+	defer currentMultiCoreGuard.Unlock()
+
+	currentMultiCore = mc
+}
+
+// RegisterSink adds or replaces a named sink on the multi-core backing
+// the current global logger.
+func RegisterSink(name string, sink zapcore.WriteSyncer, enc zapcore.Encoder, lvl zapcore.LevelEnabler) {
+	currentMultiCoreGuard.RLock()
+	defer currentMultiCoreGuard.RUnlock()
+
+	if currentMultiCore != nil {
+		currentMultiCore.RegisterSink(name, sink, enc, lvl)
+	}
+}
+
+// RemoveSink stops forwarding entries to the named sink on the
+// multi-core backing the current global logger.
+func RemoveSink(name string) {
+	currentMultiCoreGuard.RLock()
+	defer currentMultiCoreGuard.RUnlock()
+
+	if currentMultiCore != nil {
+		currentMultiCore.RemoveSink(name)
+	}
+}
+
+// reloadSinks reconciles the live multi-core against a freshly received
+// sink list: every listed sink is rebuilt and (re)registered, changed or
+// not, and sinks that were applied previously but are no longer present
+// are removed.
+// cfg.Sinks is updated to match so that an unrelated rebuild of the
+// logger (e.g. from the _LOG_LOG_LEVEL watcher, which shares the same
+// *Config) doesn't revert to the sinks cfg held at startup.
+func reloadSinks(cfg *Config, sinks []SinkConfig) {
+	encoder := buildEncoder(cfg)
+
+	appliedSinksGuard.Lock()
+	defer appliedSinksGuard.Unlock()
+
+	wanted := make(map[string]bool, len(sinks))
+	for _, sc := range sinks {
+		wanted[sc.Name] = true
+
+		sink, err := buildSink(sc)
+		if err != nil {
+			safeErrorf("Failed to build log sink %s: %v", sc.Name, err)
+			continue
+		}
+
+		sinkLevel, err := zapLevelFromString(sc.Level)
+		if err != nil {
+			sinkLevel = level
+		}
+
+		RegisterSink(sc.Name, sink, encoder, sinkLevel)
+	}
+
+	for name := range appliedSinks {
+		if !wanted[name] {
+			RemoveSink(name)
+		}
+	}
+
+	appliedSinks = wanted
+	cfg.Sinks = sinks
+}
+
+// seedAppliedSinks records the sinks New() just registered from
+// cfg.Sinks at startup, so the first live _LOG_SINKS reconciliation
+// knows about them and can remove ones no longer wanted.
+func seedAppliedSinks(sinks []SinkConfig) {
+	appliedSinksGuard.Lock()
+	defer appliedSinksGuard.Unlock()
+
+	wanted := make(map[string]bool, len(sinks))
+	for _, sc := range sinks {
+		wanted[sc.Name] = true
+	}
+
+	appliedSinks = wanted
+}
+
+// buildSink opens the zapcore.WriteSyncer described by sc.
+func buildSink(sc SinkConfig) (zapcore.WriteSyncer, error) {
+	switch sc.Type {
+	case "", "stdout":
+		return zapcore.AddSync(os.Stdout), nil
+	case "file":
+		return zapcore.AddSync(&lumberjack.Logger{Filename: sc.Path}), nil
+	case "syslog":
+		w, err := syslog.Dial("", sc.Path, syslog.LOG_INFO, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial syslog sink %s: %w", sc.Path, err)
+		}
+		return zapcore.AddSync(w), nil
+	case "tcp", "udp":
+		conn, err := net.Dial(sc.Type, sc.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial %s sink %s: %w", sc.Type, sc.Path, err)
+		}
+		return zapcore.AddSync(conn), nil
+	case "memory":
+		return NewMemorySink(0), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type: %s", sc.Type)
+	}
+}
+
+// MemorySink is an in-memory zapcore.WriteSyncer ring buffer, primarily
+// useful in tests that want to assert on emitted log lines without
+// touching stdout or the filesystem.
+type MemorySink struct {
+	mu       sync.Mutex
+	capacity int
+	lines    []string
+}
+
+// NewMemorySink returns a MemorySink retaining at most capacity lines;
+// capacity <= 0 means unbounded.
+func NewMemorySink(capacity int) *MemorySink {
+	return &MemorySink{capacity: capacity}
+}
+
+func (s *MemorySink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lines = append(s.lines, string(p))
+	if s.capacity > 0 && len(s.lines) > s.capacity {
+		s.lines = s.lines[len(s.lines)-s.capacity:]
+	}
+
+	return len(p), nil
+}
+
+// Sync is a no-op; MemorySink never buffers beyond Write.
+func (s *MemorySink) Sync() error { return nil }
+
+// Lines returns a snapshot of the retained log lines.
+func (s *MemorySink) Lines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]string, len(s.lines))
+	copy(out, s.lines)
+
+	return out
+}
@@ -0,0 +1,26 @@
+package logger
+
+import "testing"
+
+func TestReloadSinksUpdatesConfigAndRemovesStale(t *testing.T) {
+	cfg := &Config{Sinks: []SinkConfig{{Name: "startup", Type: "memory"}}}
+	New(nil, cfg)
+
+	reloadSinks(cfg, []SinkConfig{{Name: "live", Type: "memory"}})
+
+	if len(cfg.Sinks) != 1 || cfg.Sinks[0].Name != "live" {
+		t.Fatalf("expected cfg.Sinks to reflect the live reconciliation, got %+v", cfg.Sinks)
+	}
+
+	appliedSinksGuard.Lock()
+	_, startupStillApplied := appliedSinks["startup"]
+	_, liveApplied := appliedSinks["live"]
+	appliedSinksGuard.Unlock()
+
+	if startupStillApplied {
+		t.Fatalf("expected startup sink seeded by New to be removable once no longer wanted")
+	}
+	if !liveApplied {
+		t.Fatalf("expected live sink to be tracked as applied")
+	}
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	loggerv1 "github.com/uncopied/tools/logger/v1"
+)
+
+// Span is a thin alias over trace.Span so callers don't need to import
+// the OpenTelemetry package directly.
+type Span = trace.Span
+
+const tracerName = "github.com/uncopied/tools/logger"
+
+// tracePublishEnabled guards StartSpan; flipped at runtime via
+// LogFeaturesManager/WatchAndRebuildLogger.
+var tracePublishEnabled uint32
+
+// InitTracingAndLogCorrelation initializes an OTLP/gRPC tracer provider
+// pointed at agentAddress, registers it as the global OpenTelemetry
+// tracer provider, and sets the initial state of the trace-publish and
+// log-correlation toggles consulted by StartSpan and logger/v1's
+// FromContext. The returned io.Closer must be closed on shutdown to
+// flush any pending spans.
+func InitTracingAndLogCorrelation(traceEnabled, logCorrelationEnabled bool, agentAddress string) (io.Closer, error) {
+	Features.SetTracePublish(traceEnabled)
+	Features.SetLogCorrelation(logCorrelationEnabled)
+
+	exporter, err := otlptracegrpc.New(context.Background(),
+		otlptracegrpc.WithEndpoint(agentAddress),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+
+	return tracerProviderCloser{tp}, nil
+}
+
+type tracerProviderCloser struct {
+	tp *sdktrace.TracerProvider
+}
+
+func (c tracerProviderCloser) Close() error {
+	return c.tp.Shutdown(context.Background())
+}
+
+// StartSpan starts a new span named name as a child of any span found in
+// ctx and returns the derived context together with the span. Trace
+// publishing is off until InitTracingAndLogCorrelation or
+// LogFeaturesManager turns it on; while it is off, StartSpan returns ctx
+// unchanged together with the span already in it, or a no-op span if ctx
+// holds none.
+func StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	if atomic.LoadUint32(&tracePublishEnabled) == 0 {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
+
+// LogFeaturesManager exposes the OpenTelemetry features that can be
+// toggled at runtime without tearing down the tracer provider.
+type LogFeaturesManager struct{}
+
+// SetTracePublish enables or disables span creation in StartSpan.
+func (LogFeaturesManager) SetTracePublish(enabled bool) {
+	var v uint32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreUint32(&tracePublishEnabled, v)
+}
+
+// SetLogCorrelation enables or disables the trace_id/span_id injection
+// performed by logger/v1's FromContext.
+func (LogFeaturesManager) SetLogCorrelation(enabled bool) {
+	loggerv1.SetLogCorrelationEnabled(enabled)
+}
+
+// Features is the package-level LogFeaturesManager consulted by
+// WatchAndRebuildLogger.
+var Features LogFeaturesManager
@@ -0,0 +1,19 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStartSpanNoopWhenPublishingDisabled(t *testing.T) {
+	Features.SetTracePublish(false)
+
+	ctx, span := StartSpan(context.Background(), "op")
+
+	if ctx != context.Background() {
+		t.Fatal("expected ctx to be returned unchanged when trace publishing is disabled")
+	}
+	if span.SpanContext().IsValid() {
+		t.Fatal("expected a no-op span when trace publishing is disabled")
+	}
+}
@@ -3,7 +3,10 @@ package logger
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -13,6 +16,46 @@ type contextTags struct{}
 var loggerContextKey = contextKey{}
 var loggerContextTags = contextTags{}
 
+var (
+	// global is the logger FromContext falls back to when ctx carries
+	// none of its own; it's the single source of truth behind both this
+	// package's Logger/SetLogger and the root logger package's
+	// same-named wrappers.
+	global      *zap.SugaredLogger
+	globalGuard sync.RWMutex
+)
+
+// Logger returns the logger previously set via SetLogger.
+func Logger() *zap.SugaredLogger {
+	globalGuard.RLock()
+	defer globalGuard.RUnlock()
+	return global
+}
+
+// SetLogger sets the logger FromContext falls back to. SetLogger and
+// Logger are serialized by globalGuard; FromContext reads the logger
+// without taking it.
+func SetLogger(l *zap.SugaredLogger) {
+	globalGuard.Lock()
+	defer globalGuard.Unlock()
+	global = l
+}
+
+// logCorrelationEnabled guards the trace_id/span_id injection in
+// FromContext; defaults to enabled. Use SetLogCorrelationEnabled to flip
+// it at runtime, e.g. from WatchAndRebuildLogger.
+var logCorrelationEnabled uint32 = 1
+
+// SetLogCorrelationEnabled enables or disables the trace_id/span_id
+// injection performed by FromContext.
+func SetLogCorrelationEnabled(enabled bool) {
+	var v uint32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreUint32(&logCorrelationEnabled, v)
+}
+
 // ToContext returns new context with specified sugared logger inside.
 func ToContext(ctx context.Context, l *zap.SugaredLogger) context.Context {
 	return context.WithValue(ctx, loggerContextKey, l)
@@ -48,6 +91,15 @@ func ContextWithTags(ctx context.Context, tags ...string) context.Context {
 	return context.WithValue(ctx, loggerContextTags, tags)
 }
 
+// TagsFromContext returns the tags previously attached via
+// ContextWithTags, if any. It exists so other packages (e.g. the root
+// logger package's Debug/Info/... helpers) can check for tags without
+// reaching into loggerContextTags, which is unexported here.
+func TagsFromContext(ctx context.Context) ([]string, bool) {
+	tags, ok := ctx.Value(loggerContextTags).([]string)
+	return tags, ok
+}
+
 // FromContext returns logger from context if set. Otherwise returns global `global` logger.
 // In both cases returned logger is populated with `trace_id` & `span_id`.
 func FromContext(ctx context.Context) *zap.SugaredLogger {
@@ -57,5 +109,15 @@ func FromContext(ctx context.Context) *zap.SugaredLogger {
 		l = logger
 	}
 
+	if atomic.LoadUint32(&logCorrelationEnabled) == 1 {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			l = l.With(zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+		}
+	}
+
+	if fields := FieldsFromContext(ctx); len(fields) > 0 {
+		l = l.Desugar().With(fields...).Sugar()
+	}
+
 	return l
 }
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Field is a structured log field; it's a direct re-export of zap.Field
+// so callers don't need to import zap themselves just to build one.
+type Field = zap.Field
+
+// String constructs a string-valued Field.
+func String(key, val string) Field { return zap.String(key, val) }
+
+// Int64 constructs an int64-valued Field.
+func Int64(key string, val int64) Field { return zap.Int64(key, val) }
+
+// Duration constructs a time.Duration-valued Field.
+func Duration(key string, val time.Duration) Field { return zap.Duration(key, val) }
+
+// Error constructs a Field under the conventional "error" key.
+func Error(err error) Field { return zap.Error(err) }
+
+// Stringer constructs a Field from anything implementing fmt.Stringer,
+// deferring the String() call until the entry is actually encoded.
+func Stringer(key string, val fmt.Stringer) Field { return zap.Stringer(key, val) }
+
+// ByteString constructs a Field from a raw byte slice.
+func ByteString(key string, val []byte) Field { return zap.ByteString(key, val) }
+
+type contextFields struct{}
+
+var loggerContextFields = contextFields{}
+
+// ContextWithFields returns a new context carrying fields appended to
+// whatever structured fields are already attached via a previous
+// ContextWithFields call. Unlike ContextWithKV it keeps each field's
+// concrete type instead of boxing it behind zap.Any.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if existing, ok := ctx.Value(loggerContextFields).([]Field); ok {
+		fields = append(append([]Field{}, existing...), fields...)
+	}
+
+	return context.WithValue(ctx, loggerContextFields, fields)
+}
+
+// FieldsFromContext returns the structured fields accumulated on ctx via
+// ContextWithFields, so middleware can extract and forward them.
+func FieldsFromContext(ctx context.Context) []Field {
+	fields, _ := ctx.Value(loggerContextFields).([]Field)
+	return fields
+}
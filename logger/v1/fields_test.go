@@ -0,0 +1,21 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextWithFieldsAccumulates(t *testing.T) {
+	ctx := ContextWithFields(context.Background(), String("a", "1"))
+	ctx = ContextWithFields(ctx, Int64("b", 2))
+
+	if fields := FieldsFromContext(ctx); len(fields) != 2 {
+		t.Fatalf("expected 2 accumulated fields, got %d: %+v", len(fields), fields)
+	}
+}
+
+func TestFieldsFromContextEmpty(t *testing.T) {
+	if fields := FieldsFromContext(context.Background()); len(fields) != 0 {
+		t.Fatalf("expected no fields on a bare context, got %+v", fields)
+	}
+}